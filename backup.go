@@ -1,18 +1,34 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
 	"fmt"
-	"github.com/mmckeen/btrfs-backup/btrfs"
-	"github.com/mmckeen/btrfs-backup/config"
 	"io"
 	"io/ioutil"
-	"log"
 	"net"
-	"net/http"
-	"net/rpc"
 	"os"
+	"os/signal"
 	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/mmckeen/btrfs-backup/auth"
+	"github.com/mmckeen/btrfs-backup/btrfs"
+	"github.com/mmckeen/btrfs-backup/certgen"
+	"github.com/mmckeen/btrfs-backup/config"
+	"github.com/mmckeen/btrfs-backup/internal/logging"
+	"github.com/mmckeen/btrfs-backup/retention"
+	"github.com/mmckeen/btrfs-backup/rpc/btrfsbackuppb"
+	"github.com/mmckeen/btrfs-backup/scheduler"
+	"github.com/mmckeen/btrfs-backup/state"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
@@ -34,8 +50,7 @@ func realMain() int {
 	err := process()
 
 	if err != nil {
-		log.SetOutput(os.Stderr)
-		log.Printf("%s", err)
+		logging.Log.Error().Err(err).Msg("btrfs-backup failed")
 		return 1
 	}
 
@@ -49,101 +64,545 @@ func realMain() int {
 func process() error {
 
 	// parse command line args
-	subvolume_source := flag.String("subvolume", config.DefaultConfig().SubvolumePath, "Subvolume to back up.")
-	subvolume_destination_directory := flag.String("destination_subvolume", config.DefaultConfig().SubvolumeDirectoryPath,
+	subvolume_source := flag.String("subvolume", config.DefaultJobConfig().SubvolumePath, "Subvolume to back up.")
+	subvolume_destination_directory := flag.String("destination_subvolume", config.DefaultJobConfig().SubvolumeDirectoryPath,
 		"A relative path off of the subvolume path that will come to store snapshots.")
-	server := flag.Bool("server", config.DefaultConfig().Server, "Whether to enable listening as a backup server.")
-	destination_host := flag.String("host", config.DefaultConfig().DestinationHost, "Host to send backups to.")
-	destination_port := flag.Int("port", config.DefaultConfig().DestinationPort,
+	server := flag.Bool("server", false, "Whether to enable listening as a backup server.")
+	destination_host := flag.String("host", config.DefaultJobConfig().DestinationHost, "Host to send backups to.")
+	destination_port := flag.Int("port", config.DefaultJobConfig().DestinationPort,
 		"TCP port of host to send backups to.  "+
 			"Will also be the port to listen on in server mode.")
+	tls_cert := flag.String("tls-cert", "", "TLS certificate file.")
+	tls_key := flag.String("tls-key", "", "TLS private key file.")
+	client_ca := flag.String("client-ca", "",
+		"PEM bundle of CAs trusted to sign client certificates.  Enables mTLS.")
+	auth_token := flag.String("auth-token", "", "Bearer token required of callers, if set.")
+	generate_ca := flag.String("generate-ca", "",
+		"Comma-separated hostnames/IPs to generate a self-signed CA and leaf cert for, then exit.")
+	keep_hourly := flag.Int("keep-hourly", 0, "Number of hourly snapshots to keep.")
+	keep_daily := flag.Int("keep-daily", 0, "Number of daily snapshots to keep.")
+	keep_weekly := flag.Int("keep-weekly", 0, "Number of weekly snapshots to keep.")
+	keep_monthly := flag.Int("keep-monthly", 0, "Number of monthly snapshots to keep.")
+	keep_yearly := flag.Int("keep-yearly", 0, "Number of yearly snapshots to keep.")
+	dry_run := flag.Bool("dry-run", false, "Only log the snapshots retention would delete.")
+	log_level := flag.String("log-level", "info", "Log level: debug, info, warn, error.")
+	log_format := flag.String("log-format", "text", "Log format: text or json.")
+	shutdown_timeout := flag.Duration("shutdown-timeout", config.DefaultServerConfig().ShutdownTimeout,
+		"How long to wait for in-flight RPCs to finish on SIGINT/SIGTERM before forcing the server closed.")
+	config_path := flag.String("config", "",
+		"Path to a YAML/TOML config file listing scheduled jobs and a server to run. "+
+			"Jobs run on their configured cron schedules until SIGINT/SIGTERM, and the "+
+			"file is re-read on change. Ignored if --oneshot is given.")
+	oneshot := flag.Bool("oneshot", false,
+		"Run a single job once, using the flags above, instead of loading --config and scheduling jobs.")
 
 	flag.Parse()
 
+	out, err := logOutput()
+	if err != nil {
+		return err
+	}
+	if err := logging.Init(out, *log_level, *log_format); err != nil {
+		return err
+	}
+
 	// header info
 	info()
 
-	// set backup configuration
-	backupConfig := config.Config{*subvolume_source, *subvolume_destination_directory, *server, *destination_host, *destination_port}
+	if *generate_ca != "" {
+		return certgen.GenerateCA("ca.pem", "ca-key.pem", "leaf.pem", "leaf-key.pem", strings.Split(*generate_ca, ","))
+	}
 
-	// create drivers
-	btrfs_driver := new(btrfs.Btrfs)
-	btrfs_driver.BackupConfig = backupConfig
+	// cancel ctx, and so any in-flight RPC or scheduled job, on
+	// SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if *config_path != "" && !*oneshot {
+		return runScheduled(ctx, *config_path)
+	}
+
+	job := config.JobConfig{
+		SubvolumePath:          *subvolume_source,
+		SubvolumeDirectoryPath: *subvolume_destination_directory,
+		DestinationHost:        *destination_host,
+		DestinationPort:        *destination_port,
+		Retention: retention.Policy{
+			KeepHourly:  *keep_hourly,
+			KeepDaily:   *keep_daily,
+			KeepWeekly:  *keep_weekly,
+			KeepMonthly: *keep_monthly,
+			KeepYearly:  *keep_yearly,
+		},
+		DryRun: *dry_run,
+		TLS: config.TLSConfig{
+			CertFile: *tls_cert,
+			KeyFile:  *tls_key,
+			CAFile:   *client_ca,
+		},
+		AuthToken: *auth_token,
+	}
 
-	// validate
-	err := validateConfig(backupConfig, btrfs_driver)
+	serverConfig := config.ServerConfig{
+		Port:                   *destination_port,
+		SubvolumeDirectoryPath: *subvolume_destination_directory,
+		TLS: config.TLSConfig{
+			CertFile: *tls_cert,
+			KeyFile:  *tls_key,
+			CAFile:   *client_ca,
+		},
+		AuthToken:       *auth_token,
+		ShutdownTimeout: *shutdown_timeout,
+	}
 
+	btrfs_driver := new(btrfs.Btrfs)
+	btrfs_driver.BackupConfig = job
+
+	stateStore, err := state.Open(job.SubvolumeDirectoryPath + "/.btrfs-backup.db")
 	if err != nil {
+		return fmt.Errorf("opening transfer state: %v", err)
+	}
+	defer stateStore.Close()
+	btrfs_driver.State = stateStore
+
+	if err := validateConfig(job, btrfs_driver); err != nil {
 		return err
 	}
 
 	// start server if asked
-	RPC := new(btrfs.BtrfsRPC)
-	RPC.Driver = btrfs_driver
+	if *server {
+		return serve(ctx, btrfs_driver, serverConfig)
+	}
+
+	return sendMissingSubvolumes(ctx, btrfs_driver, job)
+}
+
+// runScheduled loads a Config from configPath, starts its server (if
+// enabled), and runs its jobs on their cron schedules until ctx is
+// canceled. The config file is watched for changes and the schedule
+// re-planned to match, without disturbing jobs already running.
+func runScheduled(ctx context.Context, configPath string) error {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+
+	runners := newJobRunners()
+	defer runners.closeAll()
+
+	if cfg.Server.Enabled {
+		serverDriver := new(btrfs.Btrfs)
+		serverDriver.BackupConfig = config.JobConfig{SubvolumeDirectoryPath: cfg.Server.SubvolumeDirectoryPath}
+
+		stateStore, err := state.Open(cfg.Server.SubvolumeDirectoryPath + "/.btrfs-backup.db")
+		if err != nil {
+			return fmt.Errorf("opening transfer state: %v", err)
+		}
+		defer stateStore.Close()
+		serverDriver.State = stateStore
+
+		go func() {
+			if err := serve(ctx, serverDriver, cfg.Server); err != nil {
+				logging.Log.Error().Err(err).Msg("server stopped")
+			}
+		}()
+	}
+
+	sched := scheduler.New(func(job config.JobConfig) {
+		if !runners.tryStart(job.Name) {
+			logging.Log.Warn().Str("job", job.Name).Msg("skipping firing: previous run still in progress")
+			return
+		}
+		defer runners.finish(job.Name)
+
+		driver, err := runners.driverFor(job)
+		if err != nil {
+			logging.Log.Error().Err(err).Str("job", job.Name).Msg("preparing job")
+			return
+		}
+		if err := runJob(ctx, driver, job); err != nil {
+			logging.Log.Error().Err(err).Str("job", job.Name).Msg("job failed")
+		}
+	})
+
+	if _, err := sched.Reconcile(cfg.Jobs); err != nil {
+		return err
+	}
+	sched.Start()
+	defer sched.Stop()
+
+	stopWatch, err := config.Watch(configPath, func(newCfg config.Config, err error) {
+		if err != nil {
+			// config.Watch already logged the failure; keep running the
+			// last good schedule.
+			return
+		}
+
+		removed, err := sched.Reconcile(newCfg.Jobs)
+		if err != nil {
+			logging.Log.Error().Err(err).Msg("applying reloaded config")
+			return
+		}
+		runners.forget(removed)
+		logging.Log.Info().Msg("reloaded config, schedule updated")
+	})
+	if err != nil {
+		return fmt.Errorf("watching %s: %v", configPath, err)
+	}
+	defer stopWatch()
+
+	<-ctx.Done()
+	logging.Log.Info().Msg("shutdown signal received, waiting for in-flight jobs to finish")
+
+	return nil
+}
+
+// runJob validates job's subvolume and sends whatever snapshots its
+// destination is missing.
+func runJob(ctx context.Context, driver *btrfs.Btrfs, job config.JobConfig) error {
+	if err := validateConfig(job, driver); err != nil {
+		return err
+	}
+
+	return sendMissingSubvolumes(ctx, driver, job)
+}
+
+// jobRunners keeps one *btrfs.Btrfs (and its open transfer state
+// store) per job name, reused across firings of the same scheduled
+// job so state persists between runs, and tracks which jobs are
+// currently running so a run that outlasts its own cron schedule is
+// never fired again concurrently with itself.
+type jobRunners struct {
+	mu      sync.Mutex
+	drivers map[string]*btrfs.Btrfs
+	running map[string]bool
+}
+
+func newJobRunners() *jobRunners {
+	return &jobRunners{
+		drivers: make(map[string]*btrfs.Btrfs),
+		running: make(map[string]bool),
+	}
+}
+
+// tryStart reports whether job name is not already running and, if
+// so, marks it running so a concurrent firing of the same job is
+// rejected until finish is called.
+func (r *jobRunners) tryStart(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.running[name] {
+		return false
+	}
+	r.running[name] = true
+	return true
+}
+
+// finish marks job name as no longer running, allowing its next
+// firing to proceed.
+func (r *jobRunners) finish(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.running, name)
+}
+
+// driverFor returns the driver for job, creating and opening its
+// transfer state store on first use.
+func (r *jobRunners) driverFor(job config.JobConfig) (*btrfs.Btrfs, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if driver, ok := r.drivers[job.Name]; ok {
+		driver.BackupConfig = job
+		return driver, nil
+	}
+
+	stateStore, err := state.Open(job.SubvolumeDirectoryPath + "/.btrfs-backup.db")
+	if err != nil {
+		return nil, fmt.Errorf("opening transfer state: %v", err)
+	}
+
+	driver := new(btrfs.Btrfs)
+	driver.BackupConfig = job
+	driver.State = stateStore
+	r.drivers[job.Name] = driver
+
+	return driver, nil
+}
 
-	if backupConfig.Server {
-		rpc.Register(RPC)
-		rpc.HandleHTTP()
+// forget closes and releases the drivers for the given job names, e.g.
+// once Reconcile removes them from the schedule.
+func (r *jobRunners) forget(names []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-		l, e := net.Listen("tcp", ":1234")
-		if e != nil {
-			log.Fatal("listen error:", e)
+	for _, name := range names {
+		if driver, ok := r.drivers[name]; ok {
+			if driver.State != nil {
+				driver.State.Close()
+			}
+			delete(r.drivers, name)
 		}
-		http.Serve(l, nil)
+	}
+}
 
-	} else {
-		// otherwise we are a client.  Query the client for a list of snapshots to send!
-		client, err := rpc.DialHTTP("tcp", backupConfig.DestinationHost+":"+string(backupConfig.DestinationPort))
+// closeAll closes every driver's transfer state store.
+func (r *jobRunners) closeAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for name, driver := range r.drivers {
+		if driver.State != nil {
+			driver.State.Close()
+		}
+		delete(r.drivers, name)
+	}
+}
+
+// serve starts a gRPC server exposing btrfs_driver as a BtrfsBackup
+// service. It blocks until ctx is canceled (SIGINT/SIGTERM) or the
+// listener fails, gracefully draining in-flight RPCs on shutdown.
+func serve(ctx context.Context, btrfs_driver *btrfs.Btrfs, serverConfig config.ServerConfig) error {
+	if err := btrfs_driver.CleanOrphans(btrfs_driver.BackupConfig); err != nil {
+		return fmt.Errorf("cleaning up orphaned transfers: %v", err)
+	}
+
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", serverConfig.Port))
+	if err != nil {
+		return fmt.Errorf("listen: %v", err)
+	}
+
+	var opts []grpc.ServerOption
+
+	if serverConfig.TLS.CertFile != "" {
+		tlsConfig, err := serverTLSConfig(serverConfig.TLS)
 		if err != nil {
-			log.Fatal("dialing:", err)
+			return fmt.Errorf("tls config: %v", err)
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	unaryInterceptors := []grpc.UnaryServerInterceptor{logging.UnaryServerInterceptor()}
+	streamInterceptors := []grpc.StreamServerInterceptor{logging.StreamServerInterceptor()}
+
+	if serverConfig.AuthToken != "" {
+		unaryInterceptors = append(unaryInterceptors, auth.UnaryServerInterceptor(serverConfig.AuthToken))
+		streamInterceptors = append(streamInterceptors, auth.StreamServerInterceptor(serverConfig.AuthToken))
+	}
+
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+
+	grpcServer := grpc.NewServer(opts...)
+	btrfsbackuppb.RegisterBtrfsBackupServer(grpcServer, &btrfs.Server{Driver: btrfs_driver})
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- grpcServer.Serve(l) }()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+		logging.Log.Info().Msg("shutdown signal received, draining in-flight RPCs")
+
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(serverConfig.ShutdownTimeout):
+			logging.Log.Warn().Msg("shutdown timeout exceeded, forcing connections closed")
+			grpcServer.Stop()
 		}
 
-		// Synchronous call
-		subvols, err := btrfs_driver.Subvolumes(backupConfig)
-		args := btrfs.Args{subvols}
-		var reply []string
-		err = client.Call("BtrfsRPC.SnapshotsNeeded", args, &reply)
+		return nil
+	}
+}
+
+// serverTLSConfig builds the *tls.Config for serve from the cert/key
+// pair and, if present, the client CA bundle that enables mTLS.
+func serverTLSConfig(tlsCfg config.TLSConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading server cert/key: %v", err)
+	}
+
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if tlsCfg.CAFile != "" {
+		pool, err := loadCertPool(tlsCfg.CAFile)
 		if err != nil {
-			log.Fatal("arith error:", err)
+			return nil, err
 		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
 
-		for i := 0; i < len(subvols); i++ {
-			// Send all missing snapshots to other server
-			// tell the other side to start receiving first
+	return tlsConfig, nil
+}
+
+// loadCertPool reads a PEM bundle of CA certificates from path.
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %v", path, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+
+	return pool, nil
+}
 
-			btrfs_driver.SendSubvolume(subvols[i])
+// sendMissingSubvolumes dials job's destination host, asks it which
+// subvolumes it is missing, and streams those over.
+func sendMissingSubvolumes(ctx context.Context, btrfs_driver *btrfs.Btrfs, job config.JobConfig) error {
+	ctx = auth.WithToken(ctx, job.AuthToken)
+
+	transportCreds := insecure.NewCredentials()
+	if job.TLS.CertFile != "" || job.TLS.CAFile != "" {
+		tlsConfig, err := clientTLSConfig(job.TLS)
+		if err != nil {
+			return fmt.Errorf("tls config: %v", err)
 		}
+		transportCreds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("%s:%d", job.DestinationHost, job.DestinationPort),
+		grpc.WithTransportCredentials(transportCreds),
+	)
+	if err != nil {
+		return fmt.Errorf("dialing: %v", err)
+	}
+	defer conn.Close()
+
+	client := btrfsbackuppb.NewBtrfsBackupClient(conn)
 
+	subvols, err := btrfs_driver.Subvolumes(job)
+	if err != nil {
+		return err
+	}
+
+	reply, err := client.SnapshotsNeeded(ctx, &btrfsbackuppb.SnapshotsNeededRequest{Subvolumes: subvols})
+	if err != nil {
+		return fmt.Errorf("snapshots needed: %v", err)
+	}
+
+	needed := make(map[string]bool, len(reply.Subvolumes))
+	for _, subvolume := range reply.Subvolumes {
+		needed[subvolume] = true
+	}
+
+	// confirmed starts as every local snapshot the destination didn't
+	// report needing, i.e. ones it presumably already has, so the first
+	// send in the loop below can still go incremental. Each subvolume
+	// sent successfully is added in turn, so later sends in the same
+	// run can chain off it too.
+	confirmed := make(map[string]bool, len(subvols))
+	for _, subvolume := range subvols {
+		if !needed[subvolume] {
+			confirmed[subvolume] = true
+		}
+	}
+
+	for _, subvolume := range reply.Subvolumes {
+		if err := btrfs_driver.SendSubvolume(ctx, client, subvolume, confirmed); err != nil {
+			return fmt.Errorf("send %s: %v", subvolume, err)
+		}
+		confirmed[subvolume] = true
+	}
+
+	if len(reply.Subvolumes) > 0 {
+		if err := pruneBoth(ctx, btrfs_driver, client, job); err != nil {
+			return fmt.Errorf("prune: %v", err)
+		}
 	}
 
 	return nil
 }
 
+// pruneBoth applies job's retention policy locally and, via the Prune
+// RPC, on the destination, so both ends of the backup pair keep the
+// same set of snapshots.
+func pruneBoth(ctx context.Context, btrfs_driver *btrfs.Btrfs, client btrfsbackuppb.BtrfsBackupClient, job config.JobConfig) error {
+	if _, err := btrfs_driver.Prune(job, job.Retention, job.DryRun); err != nil {
+		return fmt.Errorf("local: %v", err)
+	}
+
+	_, err := client.Prune(ctx, &btrfsbackuppb.PruneRequest{
+		KeepHourly:  int32(job.Retention.KeepHourly),
+		KeepDaily:   int32(job.Retention.KeepDaily),
+		KeepWeekly:  int32(job.Retention.KeepWeekly),
+		KeepMonthly: int32(job.Retention.KeepMonthly),
+		KeepYearly:  int32(job.Retention.KeepYearly),
+		DryRun:      job.DryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("remote: %v", err)
+	}
+
+	return nil
+}
+
+// clientTLSConfig builds the *tls.Config for sendMissingSubvolumes,
+// presenting a client certificate when one is configured (required for
+// mTLS) and trusting the configured CA bundle to verify the server.
+func clientTLSConfig(tlsCfg config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+
+	if tlsCfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client cert/key: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if tlsCfg.CAFile != "" {
+		pool, err := loadCertPool(tlsCfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
 // validate the config object
-func validateConfig(backupConfig config.Config, driver *btrfs.Btrfs) error {
+func validateConfig(job config.JobConfig, driver *btrfs.Btrfs) error {
 
 	// check to see if subvolume exists
 	// do other sanity checks
-	err := driver.Prepare(backupConfig)
+	err := driver.Prepare(job)
 	if err != nil {
 		return err
 	}
 
 	// make sure that port number makes sense
-	err = fmt.Errorf("Invalid port number: %d", backupConfig.DestinationPort)
+	err = fmt.Errorf("Invalid port number: %d", job.DestinationPort)
 
-	if backupConfig.DestinationPort > 65535 || backupConfig.DestinationPort < 1024 {
+	if job.DestinationPort > 65535 || job.DestinationPort < 1024 {
 		return err
 	}
 
 	// do initial testing of system by listing subvolumes
 	// and perform an initial snapshot for purposes of use later
-	subvols, err := driver.Subvolumes(backupConfig)
+	subvols, err := driver.Subvolumes(job)
 	if err != nil && subvols == nil {
 		return err
 	}
 
-	_, err2 := driver.Snapshot(backupConfig, "/")
+	_, err2 := driver.Snapshot(job, "/")
 	if err2 != nil {
 		return err2
 	}
@@ -153,10 +612,8 @@ func validateConfig(backupConfig config.Config, driver *btrfs.Btrfs) error {
 
 // Print some basic application info
 func info() {
-	log.SetOutput(os.Stderr)
-
-	log.Printf("Btrfs Backup Target OS/Arch: %s %s", runtime.GOOS, runtime.GOARCH)
-	log.Printf("Built with Go Version: %s", runtime.Version())
+	logging.Log.Info().Msgf("Btrfs Backup Target OS/Arch: %s %s", runtime.GOOS, runtime.GOARCH)
+	logging.Log.Info().Msgf("Built with Go Version: %s", runtime.Version())
 }
 
 // logOutput determines where we should send logs (if anywhere).
@@ -0,0 +1,557 @@
+// Package btrfs wraps the `btrfs` command line tool so the rest of the
+// application can work with subvolumes and snapshots without shelling
+// out directly.
+package btrfs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mmckeen/btrfs-backup/config"
+	"github.com/mmckeen/btrfs-backup/internal/logging"
+	"github.com/mmckeen/btrfs-backup/retention"
+	"github.com/mmckeen/btrfs-backup/rpc/btrfsbackuppb"
+	"github.com/mmckeen/btrfs-backup/state"
+)
+
+// sendChunkSize is the amount of `btrfs send` output batched into a
+// single gRPC message.
+const sendChunkSize = 256 * 1024
+
+// snapshotTimeFormat is appended to every snapshot name so that two
+// snapshots of the same subvolume never collide and so that retention
+// policies can recover the creation time without touching the
+// filesystem metadata.
+const snapshotTimeFormat = "20060102-150405"
+
+// Btrfs is the driver used to inspect and manipulate btrfs subvolumes
+// on behalf of a single backup configuration.
+type Btrfs struct {
+	BackupConfig config.JobConfig
+
+	// State persists in-flight receive progress so it survives a
+	// crash or a graceful shutdown. Nil disables persistence (state is
+	// then only tracked in memory via receives).
+	State *state.Store
+
+	// receives tracks in-flight `btrfs receive` subprocesses keyed by
+	// subvolume name, so chunks arriving over separate gRPC messages
+	// can be piped into the same process.
+	receives sync.Map // map[string]*receiveProc
+
+	// receivesMu guards starting a new entry in receives, so two
+	// ReceiveChunk calls racing to create the first chunk's process for
+	// the same subvolume can never both win and start two `btrfs
+	// receive` processes against the same destination path.
+	receivesMu sync.Mutex
+
+	// sending tracks subvolumes currently being read by SendSubvolume,
+	// so Prune never deletes a snapshot out from under an in-flight
+	// send.
+	sending sync.Map // map[string]bool
+}
+
+// receiveProc is an in-progress `btrfs receive` invocation for a
+// single subvolume.
+type receiveProc struct {
+	cmd           *exec.Cmd
+	stdin         io.WriteCloser
+	bytesReceived int64
+}
+
+// Prepare performs the sanity checks needed before a backup config can
+// be used: the subvolume must exist and be a btrfs subvolume.
+func (b *Btrfs) Prepare(cfg config.JobConfig) error {
+	out, err := exec.Command("btrfs", "subvolume", "show", cfg.SubvolumePath).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("subvolume show %s: %v: %s", cfg.SubvolumePath, err, out)
+	}
+
+	return nil
+}
+
+// Subvolumes lists the subvolumes nested under the configured backup
+// path.
+func (b *Btrfs) Subvolumes(cfg config.JobConfig) ([]string, error) {
+	out, err := exec.Command("btrfs", "subvolume", "list", cfg.SubvolumePath).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("subvolume list %s: %v: %s", cfg.SubvolumePath, err, out)
+	}
+
+	var subvols []string
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		subvols = append(subvols, fields[len(fields)-1])
+	}
+
+	return subvols, nil
+}
+
+// Snapshot creates a read-only snapshot of path and returns the
+// destination snapshot name. The name carries a timestamp suffix so
+// that retention policies can bucket snapshots by age.
+func (b *Btrfs) Snapshot(cfg config.JobConfig, path string) (string, error) {
+	base := strings.Trim(path, "/")
+	if base == "" {
+		base = "root"
+	}
+	name := fmt.Sprintf("%s-%s", base, time.Now().Format(snapshotTimeFormat))
+
+	dest := cfg.SubvolumeDirectoryPath + "/" + name
+
+	out, err := exec.Command("btrfs", "subvolume", "snapshot", "-r", path, dest).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("subvolume snapshot %s %s: %v: %s", path, dest, err, out)
+	}
+
+	return name, nil
+}
+
+// SendSubvolume streams the named snapshot to client in chunks over
+// a Send stream it opens on client, letting gRPC's flow control push back on
+// `btrfs send` when the destination falls behind. If confirmed reports
+// an earlier snapshot of the same subvolume as already present on the
+// destination, it is sent as the incremental parent (`btrfs send -p`)
+// instead of a full send, so a retry after a crash only needs to
+// transfer the delta since the last snapshot the destination actually
+// has, not every snapshot from scratch.
+func (b *Btrfs) SendSubvolume(ctx context.Context, client btrfsbackuppb.BtrfsBackupClient, subvolume string, confirmed map[string]bool) error {
+	b.sending.Store(subvolume, true)
+	defer b.sending.Delete(subvolume)
+
+	subvolPath := b.BackupConfig.SubvolumeDirectoryPath + "/" + subvolume
+	sourceUUID, err := subvolumeUUID(subvolPath)
+	if err != nil {
+		return fmt.Errorf("send %s: %v", subvolume, err)
+	}
+
+	args := []string{"send"}
+
+	var parentUUID string
+	parent, ok, err := b.parentSnapshot(subvolume, confirmed)
+	if err != nil {
+		return fmt.Errorf("send %s: finding parent: %v", subvolume, err)
+	}
+	if ok {
+		b.sending.Store(parent, true)
+		defer b.sending.Delete(parent)
+
+		parentPath := b.BackupConfig.SubvolumeDirectoryPath + "/" + parent
+		parentUUID, err = subvolumeUUID(parentPath)
+		if err != nil {
+			return fmt.Errorf("send %s: parent uuid: %v", subvolume, err)
+		}
+		args = append(args, "-p", parentPath)
+	}
+	args = append(args, subvolPath)
+
+	cmd := exec.CommandContext(ctx, "btrfs", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("send %s: stdout pipe: %v", subvolume, err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("send %s: start: %v", subvolume, err)
+	}
+
+	stream, err := client.Send(ctx)
+	if err != nil {
+		return fmt.Errorf("send %s: open stream: %v", subvolume, err)
+	}
+
+	var bytesSent int64
+	buf := make([]byte, sendChunkSize)
+	for {
+		n, readErr := stdout.Read(buf)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+			if err := stream.Send(&btrfsbackuppb.SendChunk{Subvolume: subvolume, Data: chunk, SourceUuid: sourceUUID, ParentUuid: parentUUID}); err != nil {
+				return fmt.Errorf("send %s: stream send: %v", subvolume, err)
+			}
+			bytesSent += int64(n)
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("send %s: read: %v", subvolume, readErr)
+		}
+	}
+
+	if _, err := stream.CloseAndRecv(); err != nil {
+		return fmt.Errorf("send %s: close stream: %v", subvolume, err)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return err
+	}
+
+	logging.Log.Info().Str("subvolume", subvolume).Int64("bytes_sent", bytesSent).Msg("send complete")
+	return nil
+}
+
+// parentSnapshot returns the most recent snapshot of subvolume's base
+// (i.e. sharing everything but the timestamp suffix) that confirmed
+// reports as already present on the destination, so SendSubvolume can
+// send relative to it instead of sending the whole subvolume again.
+// ok is false if no such snapshot exists, e.g. this is the base's
+// first snapshot.
+func (b *Btrfs) parentSnapshot(subvolume string, confirmed map[string]bool) (name string, ok bool, err error) {
+	subvolumeTime, err := ParseSnapshotTime(subvolume)
+	if err != nil {
+		return "", false, err
+	}
+	base := snapshotBase(subvolume)
+
+	snapshots, err := b.Snapshots(b.BackupConfig)
+	if err != nil {
+		return "", false, err
+	}
+
+	var parent retention.Snapshot
+	for _, s := range snapshots {
+		if s.Name == subvolume || !confirmed[s.Name] || snapshotBase(s.Name) != base {
+			continue
+		}
+		if s.Time.After(subvolumeTime) {
+			continue
+		}
+		if !ok || s.Time.After(parent.Time) {
+			parent, ok = s, true
+		}
+	}
+
+	return parent.Name, ok, nil
+}
+
+// snapshotBase strips the timestamp suffix Snapshot appends, so
+// snapshots of the same source path can be recognized as siblings.
+func snapshotBase(name string) string {
+	if len(name) < len(snapshotTimeFormat)+1 {
+		return name
+	}
+	return name[:len(name)-len(snapshotTimeFormat)-1]
+}
+
+// receiveProcFor returns the in-progress receiveProc for subvolume,
+// starting `btrfs receive` on the first call. Creation is guarded by
+// receivesMu and double-checked against receives so two chunks
+// arriving concurrently for a subvolume with no process yet can never
+// both start one.
+func (b *Btrfs) receiveProcFor(ctx context.Context, subvolume string) (*receiveProc, error) {
+	if procIface, ok := b.receives.Load(subvolume); ok {
+		return procIface.(*receiveProc), nil
+	}
+
+	b.receivesMu.Lock()
+	defer b.receivesMu.Unlock()
+
+	if procIface, ok := b.receives.Load(subvolume); ok {
+		return procIface.(*receiveProc), nil
+	}
+
+	cmd := exec.CommandContext(ctx, "btrfs", "receive", b.BackupConfig.SubvolumeDirectoryPath)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("receive %s: stdin pipe: %v", subvolume, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("receive %s: start: %v", subvolume, err)
+	}
+
+	proc := &receiveProc{cmd: cmd, stdin: stdin}
+	b.receives.Store(subvolume, proc)
+	return proc, nil
+}
+
+// ReceiveChunk pipes a chunk of `btrfs send` data, as received over
+// the gRPC stream, into the `btrfs receive` process for subvolume,
+// starting that process on the first chunk and persisting progress to
+// b.State so a crash mid-transfer can be recognized and rolled back on
+// the next run.
+func (b *Btrfs) ReceiveChunk(ctx context.Context, subvolume, sourceUUID, parentUUID string, data []byte) error {
+	proc, err := b.receiveProcFor(ctx, subvolume)
+	if err != nil {
+		return err
+	}
+
+	if _, err := proc.stdin.Write(data); err != nil {
+		return fmt.Errorf("receive %s: write: %v", subvolume, err)
+	}
+	proc.bytesReceived += int64(len(data))
+
+	if b.State != nil {
+		err := b.State.Save(state.Transfer{
+			Subvolume:     subvolume,
+			SourceUUID:    sourceUUID,
+			ParentUUID:    parentUUID,
+			BytesReceived: proc.bytesReceived,
+			UpdatedAt:     time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("receive %s: save state: %v", subvolume, err)
+		}
+	}
+
+	return nil
+}
+
+// FinishReceive closes the `btrfs receive` process for subvolume,
+// waits for it to exit, and clears its persisted transfer state. It is
+// called once the gRPC stream for that subvolume reaches EOF.
+func (b *Btrfs) FinishReceive(subvolume string) error {
+	proc, err := b.takeReceive(subvolume)
+	if err != nil {
+		return err
+	}
+
+	if err := proc.stdin.Close(); err != nil {
+		return fmt.Errorf("receive %s: close stdin: %v", subvolume, err)
+	}
+
+	if err := proc.cmd.Wait(); err != nil {
+		return err
+	}
+
+	if b.State != nil {
+		if err := b.State.Delete(subvolume); err != nil {
+			return fmt.Errorf("receive %s: clear state: %v", subvolume, err)
+		}
+	}
+
+	return nil
+}
+
+// AbortReceive kills an in-flight `btrfs receive` for subvolume (if
+// any is tracked in memory), deletes whatever partial subvolume it
+// wrote, and clears persisted state. It is used both when a transfer
+// is canceled mid-stream and when CleanOrphans finds a transfer left
+// behind by a process that died without a chance to roll back.
+func (b *Btrfs) AbortReceive(cfg config.JobConfig, subvolume string) error {
+	if proc, err := b.takeReceive(subvolume); err == nil {
+		proc.stdin.Close()
+		if proc.cmd.Process != nil {
+			proc.cmd.Process.Kill()
+		}
+		proc.cmd.Wait()
+	}
+
+	out, err := exec.Command("btrfs", "subvolume", "delete", cfg.SubvolumeDirectoryPath+"/"+subvolume).CombinedOutput()
+	if err != nil {
+		logging.Log.Warn().Str("subvolume", subvolume).Msgf("rollback: subvolume delete: %v: %s", err, out)
+	}
+
+	if b.State != nil {
+		if err := b.State.Delete(subvolume); err != nil {
+			return fmt.Errorf("abort %s: clear state: %v", subvolume, err)
+		}
+	}
+
+	return nil
+}
+
+// CleanOrphans rolls back every transfer left in b.State, e.g. because
+// a previous run was killed before it could call AbortReceive itself.
+// It should be called once, before a server starts accepting new
+// transfers. A rolled-back subvolume is not resumed mid-stream -
+// btrfs send/receive has no way to seek into a partial stream - but
+// because SendSubvolume sends incrementally from the destination's
+// last confirmed snapshot, the next run only has to retransmit the
+// one orphaned snapshot, not the whole subvolume's history.
+func (b *Btrfs) CleanOrphans(cfg config.JobConfig) error {
+	if b.State == nil {
+		return nil
+	}
+
+	transfers, err := b.State.List()
+	if err != nil {
+		return fmt.Errorf("listing transfer state: %v", err)
+	}
+
+	for _, t := range transfers {
+		logging.Log.Warn().Str("subvolume", t.Subvolume).Time("last_update", t.UpdatedAt).
+			Msg("rolling back orphaned transfer from a previous run")
+		if err := b.AbortReceive(cfg, t.Subvolume); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// takeReceive removes and returns the in-progress receiveProc for
+// subvolume, if one is tracked in memory.
+func (b *Btrfs) takeReceive(subvolume string) (*receiveProc, error) {
+	procIface, ok := b.receives.LoadAndDelete(subvolume)
+	if !ok || procIface == nil {
+		return nil, fmt.Errorf("receive %s: no in-flight receive", subvolume)
+	}
+
+	return procIface.(*receiveProc), nil
+}
+
+// subvolumeUUID returns the btrfs UUID of the subvolume at path.
+func subvolumeUUID(path string) (string, error) {
+	out, err := exec.Command("btrfs", "subvolume", "show", path).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("subvolume show %s: %v: %s", path, err, out)
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "UUID:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "UUID:")), nil
+		}
+	}
+
+	return "", fmt.Errorf("subvolume show %s: no UUID found", path)
+}
+
+// StreamSubvolume reads subvolume back out via `btrfs send` and hands
+// each chunk to emit, e.g. so a server can stream it to a restore
+// client.
+func (b *Btrfs) StreamSubvolume(ctx context.Context, subvolume string, emit func([]byte) error) error {
+	cmd := exec.CommandContext(ctx, "btrfs", "send", b.BackupConfig.SubvolumeDirectoryPath+"/"+subvolume)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("stream %s: stdout pipe: %v", subvolume, err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("stream %s: start: %v", subvolume, err)
+	}
+
+	buf := make([]byte, sendChunkSize)
+	for {
+		n, readErr := stdout.Read(buf)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+			if err := emit(chunk); err != nil {
+				return fmt.Errorf("stream %s: emit: %v", subvolume, err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("stream %s: read: %v", subvolume, readErr)
+		}
+	}
+
+	return cmd.Wait()
+}
+
+// ParseSnapshotTime recovers the timestamp Snapshot encoded in name's
+// suffix.
+func ParseSnapshotTime(name string) (time.Time, error) {
+	if len(name) < len(snapshotTimeFormat)+1 {
+		return time.Time{}, fmt.Errorf("snapshot name %q has no timestamp suffix", name)
+	}
+
+	suffix := name[len(name)-len(snapshotTimeFormat):]
+	return time.Parse(snapshotTimeFormat, suffix)
+}
+
+// Snapshots lists the snapshots present in cfg's snapshot directory,
+// skipping any entry whose name doesn't carry the timestamp suffix
+// Snapshot mints.
+func (b *Btrfs) Snapshots(cfg config.JobConfig) ([]retention.Snapshot, error) {
+	entries, err := os.ReadDir(cfg.SubvolumeDirectoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %v", cfg.SubvolumeDirectoryPath, err)
+	}
+
+	var snapshots []retention.Snapshot
+	for _, entry := range entries {
+		t, err := ParseSnapshotTime(entry.Name())
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, retention.Snapshot{Name: entry.Name(), Time: t})
+	}
+
+	return snapshots, nil
+}
+
+// DeleteSnapshot removes a snapshot by name from cfg's snapshot
+// directory.
+func (b *Btrfs) DeleteSnapshot(cfg config.JobConfig, name string) error {
+	out, err := exec.Command("btrfs", "subvolume", "delete", cfg.SubvolumeDirectoryPath+"/"+name).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("subvolume delete %s: %v: %s", name, err, out)
+	}
+
+	return nil
+}
+
+// inFlight reports whether subvolume is currently being sent or
+// received, and so must not be pruned.
+func (b *Btrfs) inFlight(subvolume string) bool {
+	if _, ok := b.sending.Load(subvolume); ok {
+		return true
+	}
+	if _, ok := b.receives.Load(subvolume); ok {
+		return true
+	}
+
+	return false
+}
+
+// Prune applies policy to cfg's snapshots and deletes whatever it
+// doesn't select to keep, returning the names it deleted (or, in
+// dryRun mode, would have deleted). The newest snapshot is always
+// kept, since it is the parent for the next incremental send, and a
+// snapshot currently referenced by an in-flight send or receive is
+// never deleted regardless of what policy says.
+func (b *Btrfs) Prune(cfg config.JobConfig, policy retention.Policy, dryRun bool) ([]string, error) {
+	snapshots, err := b.Snapshots(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+
+	keep := retention.Select(snapshots, policy)
+
+	newest := snapshots[0]
+	for _, s := range snapshots {
+		if s.Time.After(newest.Time) {
+			newest = s
+		}
+	}
+	keep[newest.Name] = true
+
+	var deleted []string
+	for _, s := range snapshots {
+		if keep[s.Name] || b.inFlight(s.Name) {
+			continue
+		}
+
+		if dryRun {
+			logging.Log.Info().Str("snapshot_id", s.Name).Msg("retention: would delete")
+			deleted = append(deleted, s.Name)
+			continue
+		}
+
+		if err := b.DeleteSnapshot(cfg, s.Name); err != nil {
+			return deleted, err
+		}
+		logging.Log.Info().Str("snapshot_id", s.Name).Msg("retention: deleted")
+		deleted = append(deleted, s.Name)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,120 @@
+package btrfs
+
+import (
+	"context"
+	"io"
+
+	"github.com/mmckeen/btrfs-backup/internal/logging"
+	"github.com/mmckeen/btrfs-backup/retention"
+	"github.com/mmckeen/btrfs-backup/rpc/btrfsbackuppb"
+)
+
+// Server adapts a Btrfs driver to the generated BtrfsBackup gRPC
+// service.
+type Server struct {
+	btrfsbackuppb.UnimplementedBtrfsBackupServer
+
+	Driver *Btrfs
+}
+
+// ListSubvolumes returns the subvolumes this server currently holds.
+func (s *Server) ListSubvolumes(ctx context.Context, req *btrfsbackuppb.ListSubvolumesRequest) (*btrfsbackuppb.ListSubvolumesReply, error) {
+	subvols, err := s.Driver.Subvolumes(s.Driver.BackupConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &btrfsbackuppb.ListSubvolumesReply{Subvolumes: subvols}, nil
+}
+
+// SnapshotsNeeded compares the caller's subvolume set against this
+// server's and replies with the ones the server is missing.
+func (s *Server) SnapshotsNeeded(ctx context.Context, req *btrfsbackuppb.SnapshotsNeededRequest) (*btrfsbackuppb.SnapshotsNeededReply, error) {
+	local, err := s.Driver.Subvolumes(s.Driver.BackupConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	have := make(map[string]bool, len(local))
+	for _, s := range local {
+		have[s] = true
+	}
+
+	var needed []string
+	for _, s := range req.Subvolumes {
+		if !have[s] {
+			needed = append(needed, s)
+		}
+	}
+
+	return &btrfsbackuppb.SnapshotsNeededReply{Subvolumes: needed}, nil
+}
+
+// Send receives a `btrfs send` stream in chunks and pipes it into
+// `btrfs receive` for the named subvolume.
+func (s *Server) Send(stream btrfsbackuppb.BtrfsBackup_SendServer) error {
+	var subvolume string
+	var received int64
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			if subvolume != "" {
+				if err := s.Driver.FinishReceive(subvolume); err != nil {
+					return err
+				}
+				logging.Log.Info().Str("subvolume", subvolume).Int64("bytes_sent", received).Msg("receive complete")
+			}
+			return stream.SendAndClose(&btrfsbackuppb.SendSummary{
+				Subvolume:     subvolume,
+				BytesReceived: received,
+			})
+		}
+		if err != nil {
+			if subvolume != "" {
+				if abortErr := s.Driver.AbortReceive(s.Driver.BackupConfig, subvolume); abortErr != nil {
+					logging.Log.Error().Err(abortErr).Str("subvolume", subvolume).Msg("rollback after canceled receive failed")
+				} else {
+					logging.Log.Warn().Str("subvolume", subvolume).Msg("rolled back canceled receive")
+				}
+			}
+			return err
+		}
+
+		subvolume = chunk.Subvolume
+		received += int64(len(chunk.Data))
+
+		if err := s.Driver.ReceiveChunk(stream.Context(), subvolume, chunk.SourceUuid, chunk.ParentUuid, chunk.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// Prune applies a retention policy to this server's snapshots.
+func (s *Server) Prune(ctx context.Context, req *btrfsbackuppb.PruneRequest) (*btrfsbackuppb.PruneReply, error) {
+	policy := retention.Policy{
+		KeepHourly:  int(req.KeepHourly),
+		KeepDaily:   int(req.KeepDaily),
+		KeepWeekly:  int(req.KeepWeekly),
+		KeepMonthly: int(req.KeepMonthly),
+		KeepYearly:  int(req.KeepYearly),
+	}
+
+	deleted, err := s.Driver.Prune(s.Driver.BackupConfig, policy, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	return &btrfsbackuppb.PruneReply{Deleted: deleted}, nil
+}
+
+// Receive streams a previously received subvolume back to the caller,
+// e.g. for restores.
+func (s *Server) Receive(req *btrfsbackuppb.ReceiveRequest, stream btrfsbackuppb.BtrfsBackup_ReceiveServer) error {
+	return s.Driver.StreamSubvolume(stream.Context(), req.Subvolume, func(data []byte) error {
+		return stream.Send(&btrfsbackuppb.SendChunk{
+			Subvolume: req.Subvolume,
+			Data:      data,
+		})
+	})
+}
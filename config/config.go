@@ -0,0 +1,145 @@
+// Package config holds the runtime configuration for btrfs-backup: the
+// set of scheduled backup jobs and the server that receives them.
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mmckeen/btrfs-backup/retention"
+	"github.com/spf13/viper"
+)
+
+// TLSConfig describes the certificate material used to secure one side
+// of a connection. CAFile is the bundle trusted to verify the peer: on
+// a Server it is the set of CAs allowed to sign client certificates
+// (enabling mTLS); on a Job it is the CA that signed the destination
+// server's certificate.
+type TLSConfig struct {
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+	CAFile   string `mapstructure:"ca_file"`
+}
+
+// JobConfig describes one scheduled subvolume -> host backup pairing.
+type JobConfig struct {
+	// Name identifies the job in logs and lets config reloads match a
+	// changed entry back to its running schedule.
+	Name string `mapstructure:"name"`
+
+	SubvolumePath          string `mapstructure:"subvolume"`
+	SubvolumeDirectoryPath string `mapstructure:"destination_subvolume"`
+	DestinationHost        string `mapstructure:"host"`
+	DestinationPort        int    `mapstructure:"port"`
+
+	// Schedule is a cron expression. A job with an empty Schedule is
+	// accepted but never run; --oneshot runs from flags, not from the
+	// config file's jobs.
+	Schedule string `mapstructure:"schedule"`
+
+	Retention retention.Policy `mapstructure:"retention"`
+
+	// DryRun, when true, only logs what Retention would delete.
+	DryRun bool `mapstructure:"dry_run"`
+
+	// TLS, when CertFile is set, is presented as this job's client
+	// certificate when dialing DestinationHost.
+	TLS TLSConfig `mapstructure:"tls"`
+
+	// AuthToken, when set, is sent as this job's bearer token.
+	AuthToken string `mapstructure:"auth_token"`
+}
+
+// ServerConfig describes the backup listener that receives subvolumes
+// sent by jobs running elsewhere.
+type ServerConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	Host                   string `mapstructure:"host"`
+	Port                   int    `mapstructure:"port"`
+	SubvolumeDirectoryPath string `mapstructure:"destination_subvolume"`
+
+	// TLS, when CertFile is set, is presented to callers. Setting CAFile
+	// turns on mTLS: the server requires and verifies a client
+	// certificate on every connection.
+	TLS TLSConfig `mapstructure:"tls"`
+
+	// AuthToken, when set, must be presented by every caller as a
+	// bearer token.
+	AuthToken string `mapstructure:"auth_token"`
+
+	// ShutdownTimeout bounds how long the server waits for in-flight
+	// RPCs to finish after a SIGINT/SIGTERM before forcing them closed.
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+}
+
+// Config is the top-level, file-driven configuration: the jobs to run
+// on a schedule and, optionally, a server to receive them.
+type Config struct {
+	Jobs   []JobConfig  `mapstructure:"jobs"`
+	Server ServerConfig `mapstructure:"server"`
+}
+
+// DefaultJobConfig returns the JobConfig used to seed --oneshot's flag
+// defaults.
+func DefaultJobConfig() JobConfig {
+	return JobConfig{
+		SubvolumePath:          "/",
+		SubvolumeDirectoryPath: ".btrfs-backup",
+		DestinationHost:        "localhost",
+		DestinationPort:        1234,
+	}
+}
+
+// DefaultServerConfig returns the ServerConfig used to seed --oneshot's
+// flag defaults.
+func DefaultServerConfig() ServerConfig {
+	return ServerConfig{
+		Host:                   "localhost",
+		Port:                   1234,
+		SubvolumeDirectoryPath: ".btrfs-backup",
+		ShutdownTimeout:        30 * time.Second,
+	}
+}
+
+// Load reads a YAML or TOML config file from path (the format is
+// inferred from its extension) into a Config.
+func Load(path string) (Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+
+	if err := v.ReadInConfig(); err != nil {
+		return Config{}, fmt.Errorf("reading %s: %v", path, err)
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return Config{}, fmt.Errorf("parsing %s: %v", path, err)
+	}
+
+	if err := validateJobNames(cfg.Jobs); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", path, err)
+	}
+
+	return cfg, nil
+}
+
+// validateJobNames requires every job to have a name and no two jobs
+// to share one, since the scheduler and per-job drivers are keyed by
+// Name: an empty or duplicate name would silently collide with
+// another job instead of failing to load.
+func validateJobNames(jobs []JobConfig) error {
+	seen := make(map[string]bool, len(jobs))
+
+	for i, job := range jobs {
+		if job.Name == "" {
+			return fmt.Errorf("jobs[%d]: name is required", i)
+		}
+		if seen[job.Name] {
+			return fmt.Errorf("job %q: name is not unique", job.Name)
+		}
+		seen[job.Name] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package config
+
+import "testing"
+
+func TestValidateJobNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		jobs    []JobConfig
+		wantErr bool
+	}{
+		{
+			name: "no jobs",
+			jobs: nil,
+		},
+		{
+			name: "unique names",
+			jobs: []JobConfig{{Name: "root"}, {Name: "home"}},
+		},
+		{
+			name:    "empty name",
+			jobs:    []JobConfig{{Name: "root"}, {Name: ""}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate name",
+			jobs:    []JobConfig{{Name: "root"}, {Name: "root"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateJobNames(tt.jobs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateJobNames() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
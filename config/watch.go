@@ -0,0 +1,60 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/mmckeen/btrfs-backup/internal/logging"
+)
+
+// Watch reloads path with Load whenever it changes and calls onChange
+// with the result. It watches path's directory rather than the file
+// itself, since editors and config-management tools commonly replace a
+// file rather than write it in place. Watch returns a stop function.
+// It does not call onChange for the file as it is when Watch is
+// called, only on later Write or Create events for it, and it does not
+// check whether the reloaded contents actually differ.
+func Watch(path string, onChange func(Config, error)) (stop func(), err error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	name := filepath.Base(path)
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Base(event.Name) != name {
+					continue
+				}
+				if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+					continue
+				}
+
+				cfg, err := Load(path)
+				if err != nil {
+					logging.Log.Warn().Err(err).Str("path", path).Msg("config reload failed, keeping previous config")
+				}
+				onChange(cfg, err)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logging.Log.Warn().Err(err).Msg("config watcher error")
+			}
+		}
+	}()
+
+	return func() { watcher.Close() }, nil
+}
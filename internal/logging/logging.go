@@ -0,0 +1,82 @@
+// Package logging provides the structured, leveled logger used across
+// the client and server, plus gRPC interceptors that write one log line
+// per call with its rpc_method and peer. Other lines logged while a call
+// is handled do not carry these fields.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// Log is the package-level logger. It discards everything until Init
+// is called.
+var Log = zerolog.New(io.Discard)
+
+// Init configures Log to write to out at the given level and format
+// ("text" or "json").
+func Init(out io.Writer, level, format string) error {
+	lvl, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid log level %q: %v", level, err)
+	}
+	zerolog.SetGlobalLevel(lvl)
+
+	var w io.Writer
+	switch format {
+	case "json":
+		w = out
+	case "text":
+		w = zerolog.ConsoleWriter{Out: out, TimeFormat: time.RFC3339}
+	default:
+		return fmt.Errorf("invalid log format %q, want text or json", format)
+	}
+
+	Log = zerolog.New(w).With().Timestamp().Logger()
+	return nil
+}
+
+// UnaryServerInterceptor logs every unary RPC with its method, peer
+// and outcome.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logRPC(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is the streaming-call equivalent of
+// UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logRPC(ss.Context(), info.FullMethod, start, err)
+		return err
+	}
+}
+
+func logRPC(ctx context.Context, method string, start time.Time, err error) {
+	evt := Log.Info()
+	if err != nil {
+		evt = Log.Error().Err(err)
+	}
+
+	peerAddr := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		peerAddr = p.Addr.String()
+	}
+
+	evt.Str("rpc_method", method).
+		Str("peer", peerAddr).
+		Dur("duration", time.Since(start)).
+		Msg("rpc")
+}
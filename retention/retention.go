@@ -0,0 +1,85 @@
+// Package retention implements grandfather-father-son snapshot
+// retention: keep the newest snapshot in each of the last N hourly,
+// daily, weekly, monthly and yearly buckets.
+package retention
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Policy is how many of the newest snapshot in each bucket to keep,
+// per granularity. A zero value disables that granularity.
+type Policy struct {
+	KeepHourly  int `mapstructure:"keep_hourly"`
+	KeepDaily   int `mapstructure:"keep_daily"`
+	KeepWeekly  int `mapstructure:"keep_weekly"`
+	KeepMonthly int `mapstructure:"keep_monthly"`
+	KeepYearly  int `mapstructure:"keep_yearly"`
+}
+
+// Snapshot is the minimal information Select needs about a snapshot:
+// its name (used as the result key) and the time it was taken.
+type Snapshot struct {
+	Name string
+	Time time.Time
+}
+
+// bucket pairs a granularity's keep count with the function that maps
+// a snapshot's time to that granularity's bucket key.
+type bucket struct {
+	keepN int
+	key   func(time.Time) string
+}
+
+// Select returns the set of snapshot names policy says should be kept.
+// Within each granularity, the newest snapshot in a bucket survives,
+// and only the keepN most recent buckets are considered; the union
+// across all granularities is returned. Callers are responsible for
+// any additional rules, such as never pruning the single newest
+// snapshot overall.
+func Select(snapshots []Snapshot, policy Policy) map[string]bool {
+	buckets := []bucket{
+		{policy.KeepHourly, func(t time.Time) string { return t.Format("2006010215") }},
+		{policy.KeepDaily, func(t time.Time) string { return t.Format("20060102") }},
+		{policy.KeepWeekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{policy.KeepMonthly, func(t time.Time) string { return t.Format("200601") }},
+		{policy.KeepYearly, func(t time.Time) string { return t.Format("2006") }},
+	}
+
+	keep := make(map[string]bool)
+
+	for _, b := range buckets {
+		if b.keepN <= 0 {
+			continue
+		}
+
+		newestInBucket := make(map[string]Snapshot)
+		for _, s := range snapshots {
+			key := b.key(s.Time)
+			if cur, ok := newestInBucket[key]; !ok || s.Time.After(cur.Time) {
+				newestInBucket[key] = s
+			}
+		}
+
+		keys := make([]string, 0, len(newestInBucket))
+		for k := range newestInBucket {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		if len(keys) > b.keepN {
+			keys = keys[len(keys)-b.keepN:]
+		}
+
+		for _, k := range keys {
+			keep[newestInBucket[k].Name] = true
+		}
+	}
+
+	return keep
+}
@@ -0,0 +1,91 @@
+package retention
+
+import (
+	"testing"
+	"time"
+)
+
+func mustTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("parsing %q: %v", value, err)
+	}
+	return parsed
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name      string
+		snapshots []Snapshot
+		policy    Policy
+		want      map[string]bool
+	}{
+		{
+			name:      "no snapshots",
+			snapshots: nil,
+			policy:    Policy{KeepHourly: 1},
+			want:      map[string]bool{},
+		},
+		{
+			name: "all granularities zero keeps nothing",
+			snapshots: []Snapshot{
+				{Name: "a", Time: mustTime(t, "2026-01-01T00:00:00Z")},
+			},
+			policy: Policy{},
+			want:   map[string]bool{},
+		},
+		{
+			name: "keeps newest per hourly bucket",
+			snapshots: []Snapshot{
+				{Name: "h1-early", Time: mustTime(t, "2026-01-01T10:05:00Z")},
+				{Name: "h1-late", Time: mustTime(t, "2026-01-01T10:45:00Z")},
+				{Name: "h2", Time: mustTime(t, "2026-01-01T11:05:00Z")},
+			},
+			policy: Policy{KeepHourly: 2},
+			want:   map[string]bool{"h1-late": true, "h2": true},
+		},
+		{
+			name: "only the keepN most recent buckets are considered",
+			snapshots: []Snapshot{
+				{Name: "d1", Time: mustTime(t, "2026-01-01T00:00:00Z")},
+				{Name: "d2", Time: mustTime(t, "2026-01-02T00:00:00Z")},
+				{Name: "d3", Time: mustTime(t, "2026-01-03T00:00:00Z")},
+			},
+			policy: Policy{KeepDaily: 2},
+			want:   map[string]bool{"d2": true, "d3": true},
+		},
+		{
+			name: "union across granularities",
+			snapshots: []Snapshot{
+				{Name: "recent", Time: mustTime(t, "2026-01-03T00:00:00Z")},
+				{Name: "old", Time: mustTime(t, "2020-06-15T00:00:00Z")},
+			},
+			policy: Policy{KeepDaily: 1, KeepYearly: 2},
+			want:   map[string]bool{"recent": true, "old": true},
+		},
+		{
+			name: "weekly bucket groups by ISO week across a month boundary",
+			snapshots: []Snapshot{
+				{Name: "jan31", Time: mustTime(t, "2026-01-31T00:00:00Z")},
+				{Name: "feb01", Time: mustTime(t, "2026-02-01T00:00:00Z")},
+			},
+			policy: Policy{KeepWeekly: 1},
+			want:   map[string]bool{"feb01": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Select(tt.snapshots, tt.policy)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Select() = %v, want %v", got, tt.want)
+			}
+			for name := range tt.want {
+				if !got[name] {
+					t.Errorf("Select() missing %q, got %v", name, got)
+				}
+			}
+		})
+	}
+}
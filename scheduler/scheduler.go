@@ -0,0 +1,99 @@
+// Package scheduler runs backup jobs on their configured cron
+// schedules and lets that set of jobs be replaced in place as the
+// config file changes, without disturbing jobs that are unaffected or
+// currently running.
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/mmckeen/btrfs-backup/config"
+	"github.com/robfig/cron/v3"
+)
+
+// RunFunc executes one job. It is called on the job's schedule with
+// the JobConfig in effect at that time.
+type RunFunc func(config.JobConfig)
+
+// Scheduler runs a set of jobs, identified by name, on their
+// configured cron schedules.
+type Scheduler struct {
+	cron    *cron.Cron
+	run     RunFunc
+	entries map[string]cron.EntryID
+	jobs    map[string]config.JobConfig
+}
+
+// New creates a Scheduler that invokes run on each job's schedule.
+// Start must be called for schedules to actually fire.
+func New(run RunFunc) *Scheduler {
+	return &Scheduler{
+		cron:    cron.New(),
+		run:     run,
+		entries: make(map[string]cron.EntryID),
+		jobs:    make(map[string]config.JobConfig),
+	}
+}
+
+// Start begins firing scheduled jobs in the background.
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop stops firing new jobs and waits for any currently-running job
+// to finish.
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
+
+// Reconcile replaces the running set of jobs with jobs, adding
+// schedules for new or changed jobs and removing ones no longer
+// present. A job already running when its schedule is removed or
+// changed is left to finish; only the next firing is affected. Jobs
+// with an empty Schedule are accepted but never scheduled. It returns the names of jobs that were removed,
+// so the caller can release any per-job resources it keeps keyed by
+// name.
+func (s *Scheduler) Reconcile(jobs []config.JobConfig) ([]string, error) {
+	seen := make(map[string]bool, len(jobs))
+
+	for _, job := range jobs {
+		seen[job.Name] = true
+
+		if existing, ok := s.jobs[job.Name]; ok && existing == job {
+			continue
+		}
+
+		if id, ok := s.entries[job.Name]; ok {
+			s.cron.Remove(id)
+			delete(s.entries, job.Name)
+		}
+
+		s.jobs[job.Name] = job
+
+		if job.Schedule == "" {
+			continue
+		}
+
+		job := job
+		id, err := s.cron.AddFunc(job.Schedule, func() { s.run(job) })
+		if err != nil {
+			return nil, fmt.Errorf("job %s: invalid schedule %q: %v", job.Name, job.Schedule, err)
+		}
+		s.entries[job.Name] = id
+	}
+
+	var removed []string
+	for name := range s.jobs {
+		if seen[name] {
+			continue
+		}
+		if id, ok := s.entries[name]; ok {
+			s.cron.Remove(id)
+			delete(s.entries, name)
+		}
+		delete(s.jobs, name)
+		removed = append(removed, name)
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,149 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/mmckeen/btrfs-backup/config"
+)
+
+func TestReconcileAddsScheduledJobs(t *testing.T) {
+	s := New(func(config.JobConfig) {})
+
+	removed, err := s.Reconcile([]config.JobConfig{
+		{Name: "root", Schedule: "@daily"},
+		{Name: "oneshot-only", Schedule: ""},
+	})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(removed) != 0 {
+		t.Fatalf("Reconcile() removed = %v, want none", removed)
+	}
+	if _, ok := s.entries["root"]; !ok {
+		t.Error("scheduled job \"root\" has no cron entry")
+	}
+	if _, ok := s.entries["oneshot-only"]; ok {
+		t.Error("job with empty Schedule should not get a cron entry")
+	}
+	if len(s.jobs) != 2 {
+		t.Errorf("s.jobs = %v, want 2 entries", s.jobs)
+	}
+}
+
+func TestReconcileRemovesDroppedJobs(t *testing.T) {
+	s := New(func(config.JobConfig) {})
+
+	if _, err := s.Reconcile([]config.JobConfig{
+		{Name: "root", Schedule: "@daily"},
+		{Name: "home", Schedule: "@weekly"},
+	}); err != nil {
+		t.Fatalf("initial Reconcile() error = %v", err)
+	}
+
+	removed, err := s.Reconcile([]config.JobConfig{
+		{Name: "root", Schedule: "@daily"},
+	})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(removed) != 1 || removed[0] != "home" {
+		t.Fatalf("Reconcile() removed = %v, want [home]", removed)
+	}
+	if _, ok := s.entries["home"]; ok {
+		t.Error("removed job \"home\" still has a cron entry")
+	}
+	if _, ok := s.jobs["home"]; ok {
+		t.Error("removed job \"home\" still tracked in s.jobs")
+	}
+	if _, ok := s.jobs["root"]; !ok {
+		t.Error("unchanged job \"root\" should still be tracked")
+	}
+}
+
+func TestReconcileLeavesUnchangedJobsAlone(t *testing.T) {
+	s := New(func(config.JobConfig) {})
+
+	job := config.JobConfig{Name: "root", Schedule: "@daily"}
+	if _, err := s.Reconcile([]config.JobConfig{job}); err != nil {
+		t.Fatalf("initial Reconcile() error = %v", err)
+	}
+	firstID := s.entries["root"]
+
+	if _, err := s.Reconcile([]config.JobConfig{job}); err != nil {
+		t.Fatalf("second Reconcile() error = %v", err)
+	}
+	if s.entries["root"] != firstID {
+		t.Error("Reconcile() re-registered an unchanged job's schedule")
+	}
+}
+
+func TestReconcileReplacesChangedSchedule(t *testing.T) {
+	s := New(func(config.JobConfig) {})
+
+	if _, err := s.Reconcile([]config.JobConfig{
+		{Name: "root", Schedule: "@daily"},
+	}); err != nil {
+		t.Fatalf("initial Reconcile() error = %v", err)
+	}
+	firstID := s.entries["root"]
+
+	removed, err := s.Reconcile([]config.JobConfig{
+		{Name: "root", Schedule: "@weekly"},
+	})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(removed) != 0 {
+		t.Fatalf("Reconcile() removed = %v, want none - the job still exists, just changed", removed)
+	}
+	if s.entries["root"] == firstID {
+		t.Error("Reconcile() did not replace the cron entry for a changed schedule")
+	}
+	if s.jobs["root"].Schedule != "@weekly" {
+		t.Errorf("s.jobs[root].Schedule = %q, want @weekly", s.jobs["root"].Schedule)
+	}
+}
+
+func TestReconcileReportsRemovalAfterScheduleCleared(t *testing.T) {
+	s := New(func(config.JobConfig) {})
+
+	if _, err := s.Reconcile([]config.JobConfig{
+		{Name: "root", Schedule: "@daily"},
+	}); err != nil {
+		t.Fatalf("initial Reconcile() error = %v", err)
+	}
+
+	// Clearing Schedule removes root's cron entry but keeps it in
+	// s.jobs (jobs without a schedule are still valid config).
+	if removed, err := s.Reconcile([]config.JobConfig{
+		{Name: "root", Schedule: ""},
+	}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	} else if len(removed) != 0 {
+		t.Fatalf("Reconcile() removed = %v, want none - root is still configured", removed)
+	}
+
+	// Dropping root from the config entirely must now report it
+	// removed even though it had no live cron entry to clean up.
+	removed, err := s.Reconcile(nil)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(removed) != 1 || removed[0] != "root" {
+		t.Fatalf("Reconcile() removed = %v, want [root]", removed)
+	}
+	if _, ok := s.jobs["root"]; ok {
+		t.Error("s.jobs still tracks root after it was dropped from the config")
+	}
+}
+
+func TestReconcileRejectsInvalidSchedule(t *testing.T) {
+	s := New(func(config.JobConfig) {})
+
+	_, err := s.Reconcile([]config.JobConfig{
+		{Name: "root", Schedule: "not a cron expression"},
+	})
+	if err == nil {
+		t.Fatal("Reconcile() error = nil, want an error for an invalid schedule")
+	}
+}
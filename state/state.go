@@ -0,0 +1,95 @@
+// Package state persists per-transfer progress to a bbolt database so
+// an interrupted incremental receive can be recognized and cleaned up
+// (rather than silently corrupting the destination) the next time the
+// server starts.
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var transfersBucket = []byte("transfers")
+
+// Transfer is the progress of one in-flight (or, if the process died,
+// abandoned) subvolume receive.
+type Transfer struct {
+	Subvolume     string
+	SourceUUID    string
+	ParentUUID    string
+	BytesReceived int64
+	UpdatedAt     time.Time
+}
+
+// Store wraps a bbolt database of Transfer records keyed by subvolume
+// name.
+type Store struct {
+	db *bbolt.DB
+}
+
+// Open opens (creating if necessary) the transfer state database at
+// path.
+func Open(path string) (*Store, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %v", path, err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(transfersBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating transfers bucket: %v", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save upserts a transfer's progress.
+func (s *Store) Save(t Transfer) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("marshaling transfer %s: %v", t.Subvolume, err)
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(transfersBucket).Put([]byte(t.Subvolume), data)
+	})
+}
+
+// Delete removes a transfer's progress, e.g. once it completes or is
+// rolled back.
+func (s *Store) Delete(subvolume string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(transfersBucket).Delete([]byte(subvolume))
+	})
+}
+
+// List returns every persisted transfer, e.g. to find ones abandoned
+// by a previous, interrupted run.
+func (s *Store) List() ([]Transfer, error) {
+	var transfers []Transfer
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(transfersBucket).ForEach(func(_, data []byte) error {
+			var t Transfer
+			if err := json.Unmarshal(data, &t); err != nil {
+				return err
+			}
+			transfers = append(transfers, t)
+			return nil
+		})
+	})
+
+	return transfers, err
+}